@@ -1,24 +1,37 @@
 package command
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/hashicorp/terraform/command/views"
 	"github.com/hashicorp/terraform/states"
 	"github.com/hashicorp/terraform/tfdiags"
+	"github.com/jmespath/go-jmespath"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 )
 
+// outputFormats enumerates the values accepted by the -format flag, in
+// the order they should be listed in help text.
+var outputFormats = []string{"text", "json", "raw", "yaml", "hcl", "dotenv"}
+
 // OutputCommand is a Command implementation that reads an output
 // from a Terraform state and prints it.
 type OutputCommand struct {
 	Meta
 
 	// Flags
-	name       string
-	jsonOutput bool
-	rawOutput  bool
-	statePath  string
+	name          string
+	format        string
+	query         string
+	out           string
+	jsonOutput    bool
+	rawOutput     bool
+	showSensitive bool
+	statePath     string
 }
 
 func (c *OutputCommand) Run(args []string) int {
@@ -39,24 +52,81 @@ func (c *OutputCommand) Run(args []string) int {
 		return 1
 	}
 
-	// Render the view
+	if c.query != "" {
+		queried, queryDiags := c.queryOutputs(outputs)
+		diags = diags.Append(queryDiags)
+		if diags.HasErrors() {
+			view.Diagnostics(diags)
+			return 1
+		}
+		outputs = queried
+	}
+
+	if c.out == "" {
+		// Render the view directly to the UI
+		viewDiags := view.Output(c.name, outputs)
+		diags = diags.Append(viewDiags)
+
+		view.Diagnostics(diags)
+
+		if diags.HasErrors() {
+			return 1
+		}
+
+		return 0
+	}
+
+	// With -out, render the view into a buffer so that the selected
+	// -json/-raw/-format formatting is reused unmodified, then hand the
+	// result to the requested sink instead of printing it.
+	writerSetter, ok := view.(views.OutputWriterSetter)
+	if !ok {
+		diags = diags.Append(fmt.Errorf("The selected -format does not support -out."))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	var buf bytes.Buffer
+	writerSetter.SetWriter(&buf)
+
 	viewDiags := view.Output(c.name, outputs)
 	diags = diags.Append(viewDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
 
-	view.Diagnostics(diags)
+	sink, err := views.NewOutputSink(c.out)
+	if err != nil {
+		diags = diags.Append(err)
+		view.Diagnostics(diags)
+		return 1
+	}
 
-	if diags.HasErrors() {
+	sinkOutputs := outputs
+	if c.name != "" {
+		sinkOutputs = map[string]*states.OutputValue{c.name: outputs[c.name]}
+	}
+
+	if err := sink.Write(buf.Bytes(), sinkOutputs, c.showSensitive); err != nil {
+		diags = diags.Append(err)
+		view.Diagnostics(diags)
 		return 1
 	}
 
+	view.Diagnostics(diags)
 	return 0
 }
 
 func (c *OutputCommand) ParseFlags(args []string) error {
 	args = c.Meta.process(args)
 	cmdFlags := c.Meta.defaultFlagSet("output")
+	cmdFlags.StringVar(&c.format, "format", "", "format")
 	cmdFlags.BoolVar(&c.jsonOutput, "json", false, "json")
 	cmdFlags.BoolVar(&c.rawOutput, "raw", false, "raw")
+	cmdFlags.BoolVar(&c.showSensitive, "show-sensitive", false, "show sensitive values in the output")
+	cmdFlags.StringVar(&c.query, "query", "", "query")
+	cmdFlags.StringVar(&c.out, "out", "", "out")
 	cmdFlags.StringVar(&c.statePath, "state", "", "path")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
@@ -73,8 +143,42 @@ func (c *OutputCommand) ParseFlags(args []string) error {
 		return fmt.Errorf("The -raw and -json options are mutually-exclusive.\n")
 	}
 
-	if c.rawOutput && len(args) == 0 {
-		return fmt.Errorf("You must give the name of a single output value when using the -raw option.\n")
+	if c.format != "" && (c.jsonOutput || c.rawOutput) {
+		return fmt.Errorf("The -format option is mutually-exclusive with -json and -raw.\n" +
+			"Use -format=json or -format=raw instead.\n")
+	}
+
+	if c.format == "" {
+		// -json and -raw are retained as back-compat aliases for
+		// -format=json and -format=raw.
+		switch {
+		case c.jsonOutput:
+			c.format = "json"
+		case c.rawOutput:
+			c.format = "raw"
+		default:
+			c.format = "text"
+		}
+	}
+
+	switch c.format {
+	case "text", "json", "raw", "yaml", "hcl", "dotenv":
+		// valid, whether set directly with -format or via the -json/-raw
+		// aliases above
+	default:
+		return fmt.Errorf("Unsupported -format value %q: must be one of %s.\n",
+			c.format, strings.Join(outputFormats, ", "))
+	}
+
+	if c.format == "raw" && len(args) == 0 && c.query == "" {
+		return fmt.Errorf("You must give the name of a single output value, or a -query\n" +
+			"expression that produces one, when using the -raw option.\n")
+	}
+
+	if c.query != "" && c.format != "raw" && len(args) == 0 {
+		return fmt.Errorf("You must give the name of a single output value when using -query\n" +
+			"with a format other than -raw, so the queried value has a name to be\n" +
+			"rendered under. Either add a NAME argument or use -raw.\n")
 	}
 
 	if len(args) > 0 {
@@ -86,11 +190,17 @@ func (c *OutputCommand) ParseFlags(args []string) error {
 
 func (c *OutputCommand) View() views.Output {
 	view := c.Meta.View()
-	switch {
-	case c.jsonOutput:
+	switch c.format {
+	case "json":
 		return &views.OutputJSON{View: view}
-	case c.rawOutput:
+	case "raw":
 		return &views.OutputRaw{View: view}
+	case "yaml":
+		return &views.OutputYAML{View: view}
+	case "hcl":
+		return &views.OutputHCL{View: view}
+	case "dotenv":
+		return &views.OutputDotenv{View: view, ShowSensitive: c.showSensitive}
 	default:
 		return &views.OutputText{View: view}
 	}
@@ -140,6 +250,78 @@ func (c *OutputCommand) Outputs() (map[string]*states.OutputValue, tfdiags.Diagn
 	return state.RootModule().OutputValues, nil
 }
 
+// queryOutputs evaluates c.query, a JMESPath expression, against the full
+// outputs map and returns a single-entry outputs map holding the result,
+// keyed by c.name (which may be empty). This lets the result flow through
+// the same -json/-raw/-format rendering as an ordinary output.
+func (c *OutputCommand) queryOutputs(outputs map[string]*states.OutputValue) (map[string]*states.OutputValue, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	attrs := make(map[string]cty.Value, len(outputs))
+	attrTypes := make(map[string]cty.Type, len(outputs))
+	for name, co := range outputs {
+		attrs[name] = co.Value
+		attrTypes[name] = co.Value.Type()
+	}
+	wholeVal := cty.ObjectVal(attrs)
+
+	raw, err := ctyjson.Marshal(wholeVal, cty.Object(attrTypes))
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("Error preparing outputs for -query: %s", err))
+		return nil, diags
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		diags = diags.Append(fmt.Errorf("Error preparing outputs for -query: %s", err))
+		return nil, diags
+	}
+
+	result, err := jmespath.Search(c.query, data)
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("Invalid -query expression: %s", err))
+		return nil, diags
+	}
+
+	resultRaw, err := json.Marshal(result)
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("Error encoding -query result: %s", err))
+		return nil, diags
+	}
+
+	resultType, err := ctyjson.ImpliedType(resultRaw)
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("Error encoding -query result: %s", err))
+		return nil, diags
+	}
+
+	resultVal, err := ctyjson.Unmarshal(resultRaw, resultType)
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("Error encoding -query result: %s", err))
+		return nil, diags
+	}
+
+	// We don't parse the JMESPath expression to determine exactly which
+	// outputs it reads, so conservatively treat the result as sensitive
+	// if any source output is: this ensures a query that merely passes
+	// a sensitive output through (e.g. -query 'db_password') still gets
+	// redacted/encrypted by the downstream renderers and sinks.
+	sensitive := false
+	for _, co := range outputs {
+		if co.Sensitive {
+			sensitive = true
+			break
+		}
+	}
+
+	return map[string]*states.OutputValue{
+		c.name: {
+			Value:     resultVal,
+			Sensitive: sensitive,
+		},
+	}, diags
+}
+
 func (c *OutputCommand) Help() string {
 	helpText := `
 Usage: terraform output [options] [NAME]
@@ -157,12 +339,38 @@ Options:
   -no-color        If specified, output won't contain any color.
 
   -json            If specified, machine readable output will be
-                   printed in JSON format.
+                   printed in JSON format. Shorthand for -format=json.
 
   -raw             For value types that can be automatically
                    converted to a string, will print the raw
                    string directly, rather than a human-oriented
-                   representation of the value.
+                   representation of the value. Shorthand for
+                   -format=raw.
+
+  -format=format   The rendering format for the outputs. Must be one
+                   of "text" (the default), "json", "raw", "yaml",
+                   "hcl", or "dotenv". The "raw" format is equivalent
+                   to -raw, the "hcl" format emits valid .tfvars
+                   content, and "dotenv" emits "NAME=value" lines
+                   suitable for sourcing into a shell or an env file.
+
+  -show-sensitive  If specified, sensitive values will be displayed
+                   rather than redacted.
+
+  -query=expr      A JMESPath expression to evaluate against the full
+                   outputs map before rendering. The result is rendered
+                   using the selected -format (or -json/-raw), so
+                   "-query 'foo.bar' -raw" prints a single scalar
+                   extracted from a nested output. Unless -raw is used,
+                   a NAME argument is also required, since the queried
+                   value must be rendered under some name.
+
+  -out=target      Write the rendered output to target instead of
+                   stdout. target may be a local file path, or an
+                   "s3://", "gs://", or "ssm://" URI. Local files are
+                   written atomically. The "ssm://" target writes each
+                   root output as its own AWS Systems Manager parameter,
+                   using SecureString for sensitive outputs.
 `
 	return strings.TrimSpace(helpText)
 }
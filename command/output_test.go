@@ -0,0 +1,151 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/states"
+	"github.com/mitchellh/cli"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestOutputCommand_queryOutputs(t *testing.T) {
+	outputs := map[string]*states.OutputValue{
+		"vpc": {
+			Value: cty.ObjectVal(map[string]cty.Value{
+				"subnets": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"id":   cty.StringVal("subnet-a"),
+						"tier": cty.StringVal("private"),
+					}),
+					cty.ObjectVal(map[string]cty.Value{
+						"id":   cty.StringVal("subnet-b"),
+						"tier": cty.StringVal("public"),
+					}),
+				}),
+			}),
+		},
+	}
+
+	c := &OutputCommand{
+		Meta:  Meta{Ui: new(cli.MockUi)},
+		name:  "result",
+		query: "vpc.subnets[?tier==`private`].id | [0]",
+	}
+
+	got, diags := c.queryOutputs(outputs)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	co, ok := got["result"]
+	if !ok {
+		t.Fatalf("expected a result keyed by %q, got keys %v", "result", got)
+	}
+	if got, want := co.Value.AsString(), "subnet-a"; got != want {
+		t.Fatalf("wrong value\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestOutputCommand_queryOutputsPropagatesSensitive(t *testing.T) {
+	outputs := map[string]*states.OutputValue{
+		"db_password": {Value: cty.StringVal("hunter2"), Sensitive: true},
+	}
+
+	c := &OutputCommand{
+		Meta:  Meta{Ui: new(cli.MockUi)},
+		name:  "result",
+		query: "db_password",
+	}
+
+	got, diags := c.queryOutputs(outputs)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	co, ok := got["result"]
+	if !ok {
+		t.Fatalf("expected a result keyed by %q, got keys %v", "result", got)
+	}
+	if !co.Sensitive {
+		t.Fatal("expected the query result to remain marked sensitive")
+	}
+}
+
+func TestOutputCommand_queryOutputsInvalidExpression(t *testing.T) {
+	c := &OutputCommand{
+		Meta:  Meta{Ui: new(cli.MockUi)},
+		query: "(",
+	}
+
+	_, diags := c.queryOutputs(map[string]*states.OutputValue{})
+	if !diags.HasErrors() {
+		t.Fatal("expected an error for an invalid -query expression")
+	}
+}
+
+func TestOutputCommand_ParseFlagsFormat(t *testing.T) {
+	for _, format := range outputFormats {
+		t.Run(format, func(t *testing.T) {
+			c := &OutputCommand{Meta: Meta{Ui: new(cli.MockUi)}}
+			args := []string{"-format", format}
+			if format == "raw" {
+				args = append(args, "foo")
+			}
+			if err := c.ParseFlags(args); err != nil {
+				t.Fatalf("unexpected error for -format=%s: %s", format, err)
+			}
+			if c.format != format {
+				t.Fatalf("got format %q, want %q", c.format, format)
+			}
+		})
+	}
+
+	c := &OutputCommand{Meta: Meta{Ui: new(cli.MockUi)}}
+	err := c.ParseFlags([]string{"-format", "bogus"})
+	if err == nil {
+		t.Fatal("expected an error for an unsupported -format value")
+	}
+	if !strings.Contains(err.Error(), `Unsupported -format value "bogus"`) {
+		t.Fatalf("unexpected error text: %s", err)
+	}
+	for _, format := range outputFormats {
+		if !strings.Contains(err.Error(), format) {
+			t.Fatalf("expected error text to mention %q: %s", format, err)
+		}
+	}
+}
+
+func TestOutputCommand_ParseFlagsFormatAliases(t *testing.T) {
+	c := &OutputCommand{Meta: Meta{Ui: new(cli.MockUi)}}
+	if err := c.ParseFlags([]string{"-format", "json", "-json"}); err == nil {
+		t.Fatal("expected an error when -format and -json are combined")
+	}
+
+	c = &OutputCommand{Meta: Meta{Ui: new(cli.MockUi)}}
+	if err := c.ParseFlags([]string{"-format", "raw", "-raw", "foo"}); err == nil {
+		t.Fatal("expected an error when -format and -raw are combined")
+	}
+
+	c = &OutputCommand{Meta: Meta{Ui: new(cli.MockUi)}}
+	if err := c.ParseFlags([]string{"-json", "-raw"}); err == nil {
+		t.Fatal("expected an error when -json and -raw are combined")
+	}
+}
+
+func TestOutputCommand_queryRequiresNameOrRaw(t *testing.T) {
+	c := &OutputCommand{Meta: Meta{Ui: new(cli.MockUi)}}
+	if err := c.ParseFlags([]string{"-query", "foo.bar"}); err == nil {
+		t.Fatal("expected an error when -query is used without a NAME or -raw")
+	}
+
+	c = &OutputCommand{Meta: Meta{Ui: new(cli.MockUi)}}
+	if err := c.ParseFlags([]string{"-query", "foo.bar", "-raw"}); err != nil {
+		t.Fatalf("unexpected error with -raw: %s", err)
+	}
+
+	c = &OutputCommand{Meta: Meta{Ui: new(cli.MockUi)}}
+	if err := c.ParseFlags([]string{"-query", "foo.bar", "foo"}); err != nil {
+		t.Fatalf("unexpected error with a NAME argument: %s", err)
+	}
+}
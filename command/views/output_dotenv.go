@@ -0,0 +1,90 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/tfdiags"
+)
+
+// redactedSensitiveValue is printed in place of a sensitive output's value
+// when the view has not been asked to reveal sensitive values.
+const redactedSensitiveValue = "<sensitive>"
+
+// OutputDotenv is an implementation of Output that renders outputs as
+// "NAME=value" lines, suitable for sourcing into a shell or loading as an
+// env file. Only primitive-valued outputs (string, number, bool) can be
+// flattened this way; outputs of other types are skipped with a warning.
+type OutputDotenv struct {
+	View
+
+	// ShowSensitive, if true, causes sensitive output values to be
+	// printed rather than redacted.
+	ShowSensitive bool
+}
+
+var _ Output = (*OutputDotenv)(nil)
+
+func (v *OutputDotenv) Output(name string, outputs map[string]*states.OutputValue) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	names := outputValueNames(outputs)
+	if name != "" {
+		if _, ok := outputs[name]; !ok {
+			diags = diags.Append(fmt.Errorf("Output %q not found", name))
+			return diags
+		}
+		names = []string{name}
+	}
+
+	var lines []string
+	for _, n := range names {
+		co := outputs[n]
+
+		if !co.Value.Type().IsPrimitiveType() {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Warning,
+				"Skipping non-primitive output in dotenv format",
+				fmt.Sprintf("Output %q is of a complex type and cannot be flattened to a dotenv line, so it was omitted.", n),
+			))
+			continue
+		}
+
+		var value string
+		if co.Sensitive && !v.ShowSensitive {
+			value = redactedSensitiveValue
+		} else {
+			decoded, err := decodeOutputValue(co)
+			if err != nil {
+				diags = diags.Append(fmt.Errorf("Error serializing output %q: %s", n, err))
+				continue
+			}
+			value = fmt.Sprintf("%v", decoded)
+		}
+
+		lines = append(lines, fmt.Sprintf("%s=%s", dotenvKey(n), value))
+	}
+
+	v.output(strings.Join(lines, "\n"))
+	return diags
+}
+
+func (v *OutputDotenv) Diagnostics(diags tfdiags.Diagnostics) {
+	v.View.Diagnostics(diags)
+}
+
+// dotenvKey upper-cases an output name and replaces characters that are
+// not valid in a shell environment variable name with underscores.
+func dotenvKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(name) {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/states"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestDotenvKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"db_password", "DB_PASSWORD"},
+		{"vpc-id", "VPC_ID"},
+		{"already_upper", "ALREADY_UPPER"},
+		{"has.dots", "HAS_DOTS"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := dotenvKey(test.name); got != test.want {
+				t.Errorf("dotenvKey(%q) = %q, want %q", test.name, got, test.want)
+			}
+		})
+	}
+}
+
+func TestOutputDotenv_skipsNonPrimitive(t *testing.T) {
+	view, ui := testView()
+	v := &OutputDotenv{View: view}
+
+	outputs := map[string]*states.OutputValue{
+		"name": {Value: cty.StringVal("example")},
+		"tags": {Value: cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")})},
+	}
+
+	diags := v.Output("", outputs)
+	if !diags.HasWarnings() {
+		t.Fatal("expected a warning for the non-primitive output")
+	}
+
+	got := ui.OutputWriter.String()
+	if !strings.Contains(got, "NAME=example") {
+		t.Fatalf("expected rendered output to contain NAME=example, got: %s", got)
+	}
+	if strings.Contains(got, "TAGS=") {
+		t.Fatalf("expected non-primitive output to be omitted, got: %s", got)
+	}
+}
+
+func TestOutputDotenv_redactsSensitive(t *testing.T) {
+	view, ui := testView()
+	v := &OutputDotenv{View: view}
+
+	outputs := map[string]*states.OutputValue{
+		"password": {Value: cty.StringVal("hunter2"), Sensitive: true},
+	}
+
+	if diags := v.Output("", outputs); diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	got := ui.OutputWriter.String()
+	if !strings.Contains(got, "PASSWORD=<sensitive>") {
+		t.Fatalf("expected redacted value, got: %s", got)
+	}
+}
+
+func TestOutputDotenv_showSensitive(t *testing.T) {
+	view, ui := testView()
+	v := &OutputDotenv{View: view, ShowSensitive: true}
+
+	outputs := map[string]*states.OutputValue{
+		"password": {Value: cty.StringVal("hunter2"), Sensitive: true},
+	}
+
+	if diags := v.Output("", outputs); diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	got := ui.OutputWriter.String()
+	if !strings.Contains(got, "PASSWORD=hunter2") {
+		t.Fatalf("expected revealed value, got: %s", got)
+	}
+}
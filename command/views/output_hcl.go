@@ -0,0 +1,45 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/tfdiags"
+)
+
+// OutputHCL is an implementation of Output that renders outputs as valid
+// .tfvars content, by writing each output's cty.Value as a top-level
+// attribute of a new HCL file via hclwrite.
+type OutputHCL struct {
+	View
+}
+
+var _ Output = (*OutputHCL)(nil)
+
+func (v *OutputHCL) Output(name string, outputs map[string]*states.OutputValue) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	names := outputValueNames(outputs)
+	if name != "" {
+		if _, ok := outputs[name]; !ok {
+			diags = diags.Append(fmt.Errorf("Output %q not found", name))
+			return diags
+		}
+		names = []string{name}
+	}
+
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	for _, n := range names {
+		co := outputs[n]
+		body.SetAttributeValue(n, co.Value)
+	}
+
+	v.output(string(f.Bytes()))
+	return diags
+}
+
+func (v *OutputHCL) Diagnostics(diags tfdiags.Diagnostics) {
+	v.View.Diagnostics(diags)
+}
@@ -0,0 +1,50 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/states"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestOutputHCL_nameNotFound(t *testing.T) {
+	view, _ := testView()
+	v := &OutputHCL{View: view}
+
+	diags := v.Output("missing", map[string]*states.OutputValue{})
+	if !diags.HasErrors() {
+		t.Fatal("expected an error for a missing output name")
+	}
+}
+
+func TestOutputHCL_emptyOutputs(t *testing.T) {
+	view, ui := testView()
+	v := &OutputHCL{View: view}
+
+	if diags := v.Output("", map[string]*states.OutputValue{}); diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	if got := strings.TrimSpace(ui.OutputWriter.String()); got != "" {
+		t.Fatalf("expected no attributes to be written, got: %q", got)
+	}
+}
+
+func TestOutputHCL_writesValidAttribute(t *testing.T) {
+	view, ui := testView()
+	v := &OutputHCL{View: view}
+
+	outputs := map[string]*states.OutputValue{
+		"region": {Value: cty.StringVal("us-east-1")},
+	}
+
+	if diags := v.Output("", outputs); diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	got := ui.OutputWriter.String()
+	if !strings.Contains(got, `region = "us-east-1"`) {
+		t.Fatalf("expected a valid tfvars attribute, got: %q", got)
+	}
+}
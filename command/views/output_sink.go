@@ -0,0 +1,210 @@
+package views
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/ssm"
+
+	"github.com/hashicorp/terraform/states"
+)
+
+// OutputSink is implemented by the destinations that terraform output's
+// -out flag can write to. Most sinks write the already-rendered view
+// (so that -json/-raw/-format are reused unmodified); the SSM sink
+// instead writes each root output as its own parameter, so it also
+// receives the raw outputs map.
+type OutputSink interface {
+	Write(rendered []byte, outputs map[string]*states.OutputValue, showSensitive bool) error
+}
+
+// NewOutputSink returns the OutputSink appropriate for the given -out
+// target: a local file path, or an s3://, gs://, or ssm:// URI.
+func NewOutputSink(target string) (OutputSink, error) {
+	switch {
+	case strings.HasPrefix(target, "s3://"):
+		return &s3Sink{uri: target}, nil
+	case strings.HasPrefix(target, "gs://"):
+		return &gsSink{uri: target}, nil
+	case strings.HasPrefix(target, "ssm://"):
+		return &ssmSink{prefix: strings.TrimPrefix(target, "ssm://")}, nil
+	default:
+		return &fileSink{path: target}, nil
+	}
+}
+
+// fileSink writes the rendered view to a local file, using a
+// write-to-temp-then-rename sequence so that readers never observe a
+// partially-written file.
+type fileSink struct {
+	path string
+}
+
+func (s *fileSink) Write(rendered []byte, outputs map[string]*states.OutputValue, showSensitive bool) error {
+	dir := filepath.Dir(s.path)
+	tmp, err := ioutil.TempFile(dir, filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for -out: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(rendered); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write -out target %q: %w", s.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write -out target %q: %w", s.path, err)
+	}
+
+	if err := os.Rename(tmpName, s.path); err != nil {
+		return fmt.Errorf("failed to write -out target %q: %w", s.path, err)
+	}
+	return nil
+}
+
+// s3Sink uploads the rendered view as a single S3 object.
+type s3Sink struct {
+	uri string
+}
+
+func (s *s3Sink) Write(rendered []byte, outputs map[string]*states.OutputValue, showSensitive bool) error {
+	bucket, key, err := parseBucketURI(s.uri, "s3://")
+	if err != nil {
+		return err
+	}
+
+	sess, err := session.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to create AWS session for -out: %w", err)
+	}
+	uploader := s3manager.NewUploader(sess)
+	_, err = uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   strings.NewReader(string(rendered)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write -out target %q: %w", s.uri, err)
+	}
+	return nil
+}
+
+// gsSink uploads the rendered view as a single Google Cloud Storage
+// object.
+type gsSink struct {
+	uri string
+}
+
+func (s *gsSink) Write(rendered []byte, outputs map[string]*states.OutputValue, showSensitive bool) error {
+	bucket, object, err := parseBucketURI(s.uri, "gs://")
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create GCS client for -out: %w", err)
+	}
+	defer client.Close()
+
+	w := client.Bucket(bucket).Object(object).NewWriter(ctx)
+	if _, err := w.Write(rendered); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to write -out target %q: %w", s.uri, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to write -out target %q: %w", s.uri, err)
+	}
+	return nil
+}
+
+// ssmSink writes each root output as its own AWS Systems Manager
+// Parameter Store parameter, named by joining prefix with the output's
+// name. Sensitive outputs are always written as SecureString parameters;
+// showSensitive does not change the parameter type.
+type ssmSink struct {
+	prefix string
+}
+
+func (s *ssmSink) Write(rendered []byte, outputs map[string]*states.OutputValue, showSensitive bool) error {
+	sess, err := session.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to create AWS session for -out: %w", err)
+	}
+	client := ssm.New(sess)
+
+	for name, co := range outputs {
+		value, err := decodeOutputValue(co)
+		if err != nil {
+			return fmt.Errorf("failed to encode output %q for -out: %w", name, err)
+		}
+
+		paramType := ssmParameterType(co.Sensitive)
+
+		paramValue, err := ssmParameterValue(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode output %q for -out: %w", name, err)
+		}
+
+		_, err = client.PutParameter(&ssm.PutParameterInput{
+			Name:      aws.String(strings.TrimRight(s.prefix, "/") + "/" + name),
+			Value:     aws.String(paramValue),
+			Type:      aws.String(paramType),
+			Overwrite: aws.Bool(true),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write output %q to %q: %w", name, s.prefix, err)
+		}
+	}
+	return nil
+}
+
+// ssmParameterType chooses the SSM parameter type for an output. This is
+// governed solely by whether the output itself is marked sensitive;
+// showSensitive only affects what gets displayed in the rendered buffer,
+// not how the value is persisted in Parameter Store.
+func ssmParameterType(sensitive bool) string {
+	if sensitive {
+		return ssm.ParameterTypeSecureString
+	}
+	return ssm.ParameterTypeString
+}
+
+// ssmParameterValue renders a decoded output value as the string stored in
+// an SSM parameter. Complex values (objects and lists) are JSON-encoded so
+// that consumers can parse them back out; primitive values are rendered
+// directly, without surrounding quotes.
+func ssmParameterValue(value interface{}) (string, error) {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(raw), nil
+	default:
+		return fmt.Sprintf("%v", value), nil
+	}
+}
+
+// parseBucketURI splits a "<scheme>bucket/key/with/slashes" URI into its
+// bucket and object/key components.
+func parseBucketURI(uri, scheme string) (bucket, key string, err error) {
+	trimmed := strings.TrimPrefix(uri, scheme)
+	parts := strings.SplitN(trimmed, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid -out target %q: expected %sbucket/key", uri, scheme)
+	}
+	return parts[0], parts[1], nil
+}
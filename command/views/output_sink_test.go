@@ -0,0 +1,76 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+func TestSSMParameterType(t *testing.T) {
+	if got := ssmParameterType(true); got != ssm.ParameterTypeSecureString {
+		t.Fatalf("got %q, want %q for a sensitive output", got, ssm.ParameterTypeSecureString)
+	}
+	if got := ssmParameterType(false); got != ssm.ParameterTypeString {
+		t.Fatalf("got %q, want %q for a non-sensitive output", got, ssm.ParameterTypeString)
+	}
+}
+
+func TestParseBucketURI(t *testing.T) {
+	tests := []struct {
+		uri        string
+		scheme     string
+		wantBucket string
+		wantKey    string
+		wantErr    bool
+	}{
+		{"s3://my-bucket/path/to/key", "s3://", "my-bucket", "path/to/key", false},
+		{"gs://my-bucket/key", "gs://", "my-bucket", "key", false},
+		{"s3://my-bucket", "s3://", "", "", true},
+		{"s3:///key", "s3://", "", "", true},
+		{"s3://my-bucket/", "s3://", "", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.uri, func(t *testing.T) {
+			bucket, key, err := parseBucketURI(test.uri, test.scheme)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q", test.uri)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if bucket != test.wantBucket || key != test.wantKey {
+				t.Fatalf("got (%q, %q), want (%q, %q)", bucket, key, test.wantBucket, test.wantKey)
+			}
+		})
+	}
+}
+
+func TestSSMParameterValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"string", "hello", "hello"},
+		{"number", float64(3), "3"},
+		{"bool", true, "true"},
+		{"list", []interface{}{"a", "b"}, `["a","b"]`},
+		{"object", map[string]interface{}{"a": float64(1)}, `{"a":1}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ssmParameterValue(test.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.want {
+				t.Fatalf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
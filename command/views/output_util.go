@@ -0,0 +1,40 @@
+package views
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/terraform/states"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// outputValueNames returns the names of the given outputs in sorted order,
+// so that the various views can render them deterministically.
+func outputValueNames(outputs map[string]*states.OutputValue) []string {
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// decodeOutputValue round-trips a single output's cty.Value through the
+// same JSON encoding used by OutputJSON, producing a generic Go value
+// (string, float64, bool, []interface{}, map[string]interface{}, or nil)
+// that preserves the type fidelity of the original value. This lets the
+// YAML and dotenv views and the SSM -out sink reuse the JSON encoding
+// without depending on
+// encoding/json's own (lossier) handling of cty values directly.
+func decodeOutputValue(co *states.OutputValue) (interface{}, error) {
+	raw, err := ctyjson.Marshal(co.Value, co.Value.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
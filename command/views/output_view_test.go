@@ -0,0 +1,12 @@
+package views
+
+import (
+	"github.com/mitchellh/cli"
+)
+
+// testView builds a View wired to a cli.MockUi, for use by the
+// OutputYAML/OutputHCL/OutputDotenv tests.
+func testView() (View, *cli.MockUi) {
+	ui := new(cli.MockUi)
+	return NewView(ui, false, false, 0, 0, func() map[string][]byte { return nil }), ui
+}
@@ -0,0 +1,71 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/tfdiags"
+	"gopkg.in/yaml.v2"
+)
+
+// OutputYAML is an implementation of Output that renders outputs as YAML,
+// mirroring the type fidelity of OutputJSON's encoding.
+type OutputYAML struct {
+	View
+}
+
+var _ Output = (*OutputYAML)(nil)
+
+func (v *OutputYAML) Output(name string, outputs map[string]*states.OutputValue) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	if name != "" {
+		co, ok := outputs[name]
+		if !ok {
+			diags = diags.Append(fmt.Errorf("Output %q not found", name))
+			return diags
+		}
+
+		value, err := decodeOutputValue(co)
+		if err != nil {
+			diags = diags.Append(fmt.Errorf("Error serializing output %q: %s", name, err))
+			return diags
+		}
+
+		src, err := yaml.Marshal(value)
+		if err != nil {
+			diags = diags.Append(fmt.Errorf("Error marshalling output %q as YAML: %s", name, err))
+			return diags
+		}
+		v.output(string(src))
+		return diags
+	}
+
+	if len(outputs) == 0 {
+		v.output("{}")
+		return diags
+	}
+
+	values := make(map[string]interface{}, len(outputs))
+	for _, name := range outputValueNames(outputs) {
+		value, err := decodeOutputValue(outputs[name])
+		if err != nil {
+			diags = diags.Append(fmt.Errorf("Error serializing output %q: %s", name, err))
+			return diags
+		}
+		values[name] = value
+	}
+
+	src, err := yaml.Marshal(values)
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("Error marshalling outputs as YAML: %s", err))
+		return diags
+	}
+
+	v.output(string(src))
+	return diags
+}
+
+func (v *OutputYAML) Diagnostics(diags tfdiags.Diagnostics) {
+	v.View.Diagnostics(diags)
+}
@@ -0,0 +1,49 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/states"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestOutputYAML_nameNotFound(t *testing.T) {
+	view, _ := testView()
+	v := &OutputYAML{View: view}
+
+	diags := v.Output("missing", map[string]*states.OutputValue{})
+	if !diags.HasErrors() {
+		t.Fatal("expected an error for a missing output name")
+	}
+}
+
+func TestOutputYAML_emptyOutputs(t *testing.T) {
+	view, ui := testView()
+	v := &OutputYAML{View: view}
+
+	if diags := v.Output("", map[string]*states.OutputValue{}); diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	if got := strings.TrimSpace(ui.OutputWriter.String()); got != "{}" {
+		t.Fatalf("expected {}, got: %q", got)
+	}
+}
+
+func TestOutputYAML_singleName(t *testing.T) {
+	view, ui := testView()
+	v := &OutputYAML{View: view}
+
+	outputs := map[string]*states.OutputValue{
+		"count": {Value: cty.NumberIntVal(3)},
+	}
+
+	if diags := v.Output("count", outputs); diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	if got := strings.TrimSpace(ui.OutputWriter.String()); got != "3" {
+		t.Fatalf("expected 3, got: %q", got)
+	}
+}
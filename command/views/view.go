@@ -1,6 +1,9 @@
 package views
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/hashicorp/terraform/command/format"
 	"github.com/hashicorp/terraform/tfdiags"
 	"github.com/mitchellh/cli"
@@ -14,6 +17,11 @@ type View struct {
 	outputColumns   int
 	errorColumns    int
 	configSources   func() map[string][]byte
+
+	// writer, if set, receives rendered output in place of ui. This is
+	// used by views that support streaming their result to a
+	// destination other than stdout, such as OutputCommand's -out flag.
+	writer io.Writer
 }
 
 func NewView(ui cli.Ui, color, compactWarnings bool, outputColumns, errorColumns int, configSources func() map[string][]byte) View {
@@ -32,9 +40,28 @@ func NewView(ui cli.Ui, color, compactWarnings bool, outputColumns, errorColumns
 }
 
 func (v *View) output(s string) {
+	if v.writer != nil {
+		fmt.Fprintln(v.writer, s)
+		return
+	}
 	v.ui.Output(s)
 }
 
+// SetWriter redirects this view's rendered output to w instead of its
+// usual cli.Ui, without altering how the output is formatted. Views that
+// embed View gain this capability, and satisfy OutputWriterSetter, for
+// free.
+func (v *View) SetWriter(w io.Writer) {
+	v.writer = w
+}
+
+// OutputWriterSetter is implemented by views that can redirect their
+// rendered content to an alternate io.Writer, for use with
+// OutputCommand's -out flag.
+type OutputWriterSetter interface {
+	SetWriter(w io.Writer)
+}
+
 func (v *View) Diagnostics(diags tfdiags.Diagnostics) {
 	diags.Sort()
 